@@ -0,0 +1,138 @@
+// Package dispatcher runs validate/release-note jobs on a bounded worker
+// pool, guaranteeing at most one job of each type per pull request runs at a
+// time and shedding load instead of spawning unbounded goroutines under a webhook
+// storm.
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// JobType identifies which kind of background work a Job performs.
+type JobType string
+
+const (
+	JobValidate     JobType = "validate"
+	JobReleaseNotes JobType = "release_notes"
+	JobTagRelease   JobType = "tag_release"
+)
+
+// Job is a unit of work enqueued from a webhook request.
+type Job struct {
+	Repository string
+	PRNumber   int
+	Type       JobType
+	Run        func(ctx context.Context)
+}
+
+// key identifies the {repo, pr_number, job_type} a Job's per-PR mutex guards.
+func (j Job) key() string {
+	return fmt.Sprintf("%s#%d#%s", j.Repository, j.PRNumber, j.Type)
+}
+
+// ErrQueueFull is returned by Enqueue when the worker pool's backlog is full.
+var ErrQueueFull = errors.New("dispatcher: queue is full")
+
+// ErrRateLimited is returned by Enqueue when the repository has exceeded its
+// configured event rate.
+var ErrRateLimited = errors.New("dispatcher: rate limit exceeded")
+
+// Dispatcher owns a bounded worker pool, a per-{repo,pr,job_type} mutex set,
+// and an optional rate limiter.
+type Dispatcher struct {
+	jobs    chan Job
+	limiter *RateLimiter
+	locks   *keyLocks
+
+	// enqueueMu serializes EnqueueAll so a batch's capacity/rate-limit check
+	// and the actual send happen as one atomic step.
+	enqueueMu sync.Mutex
+
+	queueDepth int64
+	inFlight   int64
+
+	wg sync.WaitGroup
+}
+
+// New starts a Dispatcher with workers goroutines draining a queue of
+// queueSize jobs. limiter may be nil to disable rate limiting.
+func New(workers, queueSize int, limiter *RateLimiter) *Dispatcher {
+	d := &Dispatcher{
+		jobs:    make(chan Job, queueSize),
+		limiter: limiter,
+		locks:   newKeyLocks(),
+	}
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+	return d
+}
+
+// Enqueue accepts job for background execution, or rejects it with
+// ErrRateLimited / ErrQueueFull so the caller can respond to the webhook
+// with a retry-able status instead of blocking.
+func (d *Dispatcher) Enqueue(job Job) error {
+	return d.EnqueueAll([]Job{job})
+}
+
+// EnqueueAll accepts every job in jobs for background execution as a single
+// atomic operation: it reserves rate-limit budget and queue capacity for all
+// of them before enqueueing any. Without this, a batch enqueued one job at a
+// time could partially land (e.g. "validate" enqueued, "release_notes"
+// rejected) and then, when the forge retries the whole webhook delivery,
+// "validate" would be enqueued and run a second time for the same action.
+// jobs must all share the same Repository; only the first job's Repository
+// is consulted for rate limiting.
+func (d *Dispatcher) EnqueueAll(jobs []Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	d.enqueueMu.Lock()
+	defer d.enqueueMu.Unlock()
+
+	if d.limiter != nil && !d.limiter.AllowN(jobs[0].Repository, len(jobs)) {
+		return ErrRateLimited
+	}
+	if len(d.jobs)+len(jobs) > cap(d.jobs) {
+		return ErrQueueFull
+	}
+	for _, job := range jobs {
+		d.jobs <- job
+		atomic.AddInt64(&d.queueDepth, 1)
+	}
+	return nil
+}
+
+// QueueDepth returns the number of jobs currently waiting for a worker.
+func (d *Dispatcher) QueueDepth() int64 {
+	return atomic.LoadInt64(&d.queueDepth)
+}
+
+// InFlight returns the number of jobs currently running.
+func (d *Dispatcher) InFlight() int64 {
+	return atomic.LoadInt64(&d.inFlight)
+}
+
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for job := range d.jobs {
+		atomic.AddInt64(&d.queueDepth, -1)
+		d.run(job)
+	}
+}
+
+func (d *Dispatcher) run(job Job) {
+	unlock := d.locks.lock(job.key())
+	defer unlock()
+
+	atomic.AddInt64(&d.inFlight, 1)
+	defer atomic.AddInt64(&d.inFlight, -1)
+
+	job.Run(context.Background())
+}
@@ -0,0 +1,56 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func noopJob(repo string, n int, typ JobType) Job {
+	return Job{Repository: repo, PRNumber: n, Type: typ, Run: func(ctx context.Context) {}}
+}
+
+func TestDispatcher_EnqueueAll_QueueFullRejectsWholeBatch(t *testing.T) {
+	d := New(0, 2, nil)
+
+	jobs := []Job{
+		noopJob("repo", 1, JobValidate),
+		noopJob("repo", 2, JobValidate),
+		noopJob("repo", 3, JobValidate),
+	}
+	if err := d.EnqueueAll(jobs); err != ErrQueueFull {
+		t.Fatalf("EnqueueAll() error = %v, want %v", err, ErrQueueFull)
+	}
+	if depth := d.QueueDepth(); depth != 0 {
+		t.Fatalf("QueueDepth() = %d, want 0: a rejected batch must not partially land", depth)
+	}
+
+	if err := d.EnqueueAll(jobs[:2]); err != nil {
+		t.Fatalf("EnqueueAll() error = %v, want nil for a batch that fits", err)
+	}
+	if depth := d.QueueDepth(); depth != 2 {
+		t.Fatalf("QueueDepth() = %d, want 2", depth)
+	}
+}
+
+func TestDispatcher_EnqueueAll_RateLimitRejectsWholeBatch(t *testing.T) {
+	limiter := NewRateLimiter(2, time.Minute)
+	d := New(0, 10, limiter)
+
+	jobs := []Job{
+		noopJob("repo", 1, JobValidate),
+		noopJob("repo", 2, JobValidate),
+		noopJob("repo", 3, JobValidate),
+	}
+	if err := d.EnqueueAll(jobs); err != ErrRateLimited {
+		t.Fatalf("EnqueueAll() error = %v, want %v", err, ErrRateLimited)
+	}
+	if depth := d.QueueDepth(); depth != 0 {
+		t.Fatalf("QueueDepth() = %d, want 0: a rate-limited batch must not partially land", depth)
+	}
+
+	// The rejected batch must not have partially consumed the rate budget.
+	if !limiter.AllowN("repo", 2) {
+		t.Fatal("AllowN(2) = false after a rejected 3-job batch, want true: budget was partially consumed")
+	}
+}
@@ -0,0 +1,49 @@
+package dispatcher
+
+import "sync"
+
+// keyLocks hands out a *sync.Mutex per string key, so jobs sharing a key
+// (the same {repo, pr_number, job_type}) serialize while unrelated jobs run
+// concurrently. Entries are reference-counted and evicted once their last
+// holder releases them, so a long-running process doesn't accumulate one
+// mutex per key ever seen (e.g. every PR number a repo has produced).
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*refCountedMutex
+}
+
+// refCountedMutex is a sync.Mutex plus the number of goroutines currently
+// holding or waiting on it, so keyLocks knows when it's safe to evict.
+type refCountedMutex struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newKeyLocks() *keyLocks {
+	return &keyLocks{locks: make(map[string]*refCountedMutex)}
+}
+
+// lock blocks until key's mutex is free, then returns a function that
+// releases it and evicts key's entry once nothing else is waiting on it.
+func (k *keyLocks) lock(key string) func() {
+	k.mu.Lock()
+	m, ok := k.locks[key]
+	if !ok {
+		m = &refCountedMutex{}
+		k.locks[key] = m
+	}
+	m.refs++
+	k.mu.Unlock()
+
+	m.mu.Lock()
+	return func() {
+		m.mu.Unlock()
+
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		m.refs--
+		if m.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package dispatcher
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ysugimoto/doorkeeper/errors"
+)
+
+// MetricsHandler renders d's queue depth and in-flight job count, plus the
+// error-class counters recorded while handling webhooks, so it can be
+// mounted at e.g. `/metrics`.
+func MetricsHandler(d *Dispatcher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintf(w, "doorkeeper_dispatcher_queue_depth %d\n", d.QueueDepth())
+		fmt.Fprintf(w, "doorkeeper_dispatcher_in_flight %d\n", d.InFlight())
+		errors.WriteMetrics(w)
+	})
+}
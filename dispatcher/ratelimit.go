@@ -0,0 +1,59 @@
+package dispatcher
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a per-key sliding-window limiter: at most limit events are
+// allowed per key within window.
+type RateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	events map[string][]time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing limit events per window, per key.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		events: make(map[string][]time.Time),
+	}
+}
+
+// Allow records an event for key and reports whether it is within the
+// configured rate, evicting timestamps that have slid out of the window.
+func (r *RateLimiter) Allow(key string) bool {
+	return r.AllowN(key, 1)
+}
+
+// AllowN is Allow's batch form: it reports whether n additional events for
+// key fit within the configured rate, and if so reserves all n at once so a
+// batch of events either all count against the limit or none do.
+func (r *RateLimiter) AllowN(key string, n int) bool {
+	now := time.Now()
+	cutoff := now.Add(-r.window)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.events[key][:0]
+	for _, t := range r.events[key] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept)+n > r.limit {
+		r.events[key] = kept
+		return false
+	}
+
+	for i := 0; i < n; i++ {
+		kept = append(kept, now)
+	}
+	r.events[key] = kept
+	return true
+}
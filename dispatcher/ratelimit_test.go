@@ -0,0 +1,44 @@
+package dispatcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_AllowN(t *testing.T) {
+	r := NewRateLimiter(3, time.Minute)
+
+	if !r.AllowN("repo", 2) {
+		t.Fatal("AllowN(2) = false on an empty limiter, want true")
+	}
+	if r.AllowN("repo", 2) {
+		t.Fatal("AllowN(2) = true with only 1 of 3 slots left, want false")
+	}
+	// The rejected call above must not have reserved anything.
+	if !r.AllowN("repo", 1) {
+		t.Fatal("AllowN(1) = false after a rejected AllowN(2) left room for 1, want true: rejection was not atomic")
+	}
+	if r.AllowN("repo", 1) {
+		t.Fatal("AllowN(1) = true once the limit (3) is fully used, want false")
+	}
+}
+
+func TestRateLimiter_AllowN_EvictsStaleEvents(t *testing.T) {
+	r := NewRateLimiter(1, time.Minute)
+	r.events["repo"] = []time.Time{time.Now().Add(-2 * time.Minute)}
+
+	if !r.AllowN("repo", 1) {
+		t.Fatal("AllowN(1) = false, want true: the only recorded event is outside the window and should have been evicted")
+	}
+}
+
+func TestRateLimiter_AllowN_DifferentKeysDoNotShareBudget(t *testing.T) {
+	r := NewRateLimiter(1, time.Minute)
+
+	if !r.AllowN("repo-a", 1) {
+		t.Fatal("AllowN(1) for repo-a = false, want true")
+	}
+	if !r.AllowN("repo-b", 1) {
+		t.Fatal("AllowN(1) for repo-b = false, want true: a different key must not share repo-a's budget")
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import "fmt"
+
+// Issue is the nested `issue` object of an issue_comment webhook payload.
+// PullRequest is non-nil when the comment was left on a pull request rather
+// than a plain issue.
+type Issue struct {
+	Number      int          `json:"number"`
+	PullRequest *PullRequest `json:"pull_request,omitempty"`
+	// URL is the API URL of the issue/pull request itself, used to build
+	// CommentURL the same way ReviewURL is built off PullRequest.URL.
+	URL string `json:"url"`
+}
+
+// Comment is the nested `comment` object shared by issue_comment and
+// pull_request_review webhook payloads.
+type Comment struct {
+	Body string `json:"body"`
+}
+
+// IssueCommentEvent is the forge-neutral representation of a comment left on
+// an issue or pull request.
+type IssueCommentEvent struct {
+	Action     string     `json:"action"`
+	Issue      Issue      `json:"issue"`
+	Comment    Comment    `json:"comment"`
+	Repository Repository `json:"repository"`
+}
+
+// IsPullRequest reports whether the comment was left on a pull request.
+func (e IssueCommentEvent) IsPullRequest() bool {
+	return e.Issue.PullRequest != nil
+}
+
+// CommentURL returns the API URL to post a reply comment to, built off the
+// issue's own API URL the same way ReviewURL is built off the pull request's.
+func (e IssueCommentEvent) CommentURL() string {
+	return fmt.Sprintf("%s/comments", e.Issue.URL)
+}
+
+// Review is the nested `review` object of a pull_request_review webhook payload.
+type Review struct {
+	Body  string `json:"body"`
+	State string `json:"state"`
+}
+
+// PullRequestReviewEvent is the forge-neutral representation of a review
+// submitted on a pull request.
+type PullRequestReviewEvent struct {
+	Action      string      `json:"action"`
+	Review      Review      `json:"review"`
+	PullRequest PullRequest `json:"pull_request"`
+	Number      int         `json:"number"`
+	Repository  Repository  `json:"repository"`
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PullRequestEvent is the forge-neutral representation of a pull/merge
+// request webhook payload, populated by a forge.Forge adapter from whichever
+// shape the origin server (Github, GitLab, Gitea, ...) actually sends.
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+}
+
+// PullRequest is the nested pull/merge request object of the webhook payload.
+type PullRequest struct {
+	Title string         `json:"title"`
+	Body  string         `json:"body"`
+	Base  PullRequestRef `json:"base"`
+	Head  PullRequestRef `json:"head"`
+	// URL is the API URL of the pull/merge request itself (Github decodes
+	// this straight off the webhook payload; Gitlab/Gitea adapters build it
+	// themselves since their payloads don't carry an equivalent field).
+	URL string `json:"url"`
+	// CommitsURL is the API URL listing the commits on this pull/merge
+	// request, populated the same way as URL.
+	CommitsURL string `json:"commits_url"`
+	// CommentsURL is the API URL to post a plain (non-review) comment to
+	// this pull/merge request, populated the same way as URL.
+	CommentsURL string `json:"comments_url"`
+}
+
+// PullRequestRef points to a branch on a pull/merge request.
+type PullRequestRef struct {
+	Ref string `json:"ref"`
+}
+
+// Repository identifies the repository the event was sent for.
+type Repository struct {
+	FullName    string `json:"full_name"`
+	ContentsURL string `json:"contents_url"`
+	StatusesURL string `json:"statuses_url"`
+	ReleasesURL string `json:"releases_url"`
+}
+
+// PushEvent is the forge-neutral representation of a push webhook payload.
+type PushEvent struct {
+	Ref        string       `json:"ref"`
+	Repository Repository   `json:"repository"`
+	Commits    []PushCommit `json:"commits"`
+}
+
+// PushCommit is one entry of a push event's embedded commit list.
+type PushCommit struct {
+	Message string `json:"message"`
+}
+
+// GithubStatus is the body sent to the commit status API.
+type GithubStatus struct {
+	Status      string `json:"state"`
+	Context     string `json:"context"`
+	Description string `json:"description"`
+}
+
+// GithubReview is the body sent to the pull request review API.
+type GithubReview struct {
+	Body  string `json:"body"`
+	Event string `json:"event"`
+}
+
+// Release is the body sent to the release-creation API.
+type Release struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
+
+// Deprecated: use PullRequestEvent. Kept so existing Github-specific code
+// compiles unchanged while the forge abstraction lands.
+type GithubPullRequestEvent = PullRequestEvent
+
+// Deprecated: use PushEvent. Kept so existing Github-specific code compiles
+// unchanged while the forge abstraction lands.
+type GithubPushEvent = PushEvent
+
+// BaseBranch returns the pull request's base (target) branch name.
+func (e PullRequestEvent) BaseBranch() string {
+	return e.PullRequest.Base.Ref
+}
+
+// ContentURL builds the contents API URL for the given path in the repository.
+func (e PullRequestEvent) ContentURL(path string) string {
+	return fmt.Sprintf("%s%s", e.Repository.ContentsURL, path)
+}
+
+// StatusURL returns the commit status API URL for this pull request's head commit.
+func (e PullRequestEvent) StatusURL() string {
+	return e.Repository.StatusesURL
+}
+
+// ReviewURL returns the review API URL for this pull request, built off the
+// pull request's own API URL the same way ContentURL/StatusURL are built off
+// the repository's.
+func (e PullRequestEvent) ReviewURL() string {
+	return fmt.Sprintf("%s/reviews", e.PullRequest.URL)
+}
+
+// CommitsURL returns the API URL listing the commits on this pull request.
+func (e PullRequestEvent) CommitsURL() string {
+	return e.PullRequest.CommitsURL
+}
+
+// CommentURL returns the API URL to post a plain comment to this pull
+// request, as opposed to ReviewURL which creates a review. Forges that don't
+// treat the two as distinct endpoints (e.g. GitLab) post reviews to this same
+// URL.
+func (e PullRequestEvent) CommentURL() string {
+	return e.PullRequest.CommentsURL
+}
+
+// ContentURL builds the contents API URL for the given path in the repository.
+func (e PushEvent) ContentURL(path string) string {
+	return fmt.Sprintf("%s%s", e.Repository.ContentsURL, path)
+}
+
+// ReleasesURL returns the API URL to create a release at.
+func (e PushEvent) ReleasesURL() string {
+	return trimURLTemplate(e.Repository.ReleasesURL)
+}
+
+// trimURLTemplate strips a RFC 6570 URI template suffix (e.g. "{/id}") from
+// a Github-style templated API URL, leaving the concrete base.
+func trimURLTemplate(u string) string {
+	if i := strings.IndexByte(u, '{'); i >= 0 {
+		return u[:i]
+	}
+	return u
+}
+
+// CommitMessages returns the commit messages embedded in the push payload.
+func (e PushEvent) CommitMessages() []string {
+	messages := make([]string, 0, len(e.Commits))
+	for _, c := range e.Commits {
+		messages = append(messages, c.Message)
+	}
+	return messages
+}
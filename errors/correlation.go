@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewCorrelationID returns a short random identifier to tie a logged
+// ServiceFault back to the friendly message a user sees.
+func NewCorrelationID() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
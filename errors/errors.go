@@ -0,0 +1,67 @@
+// Package errors classifies the failures doorkeeper can hit while handling
+// a webhook so callers can react differently to a bad pull request, a fault
+// in doorkeeper or a dependency, and a forge rate limit.
+package errors
+
+import "fmt"
+
+// UserError wraps a failure caused by the user's input (an invalid title, a
+// missing description section, ...). The remedy is on them, not doorkeeper.
+type UserError struct {
+	Cause error
+}
+
+// NewUserError wraps cause as a UserError.
+func NewUserError(cause error) *UserError {
+	return &UserError{Cause: cause}
+}
+
+func (e *UserError) Error() string {
+	return e.Cause.Error()
+}
+
+// Unwrap lets errors.Is/errors.As see through to Cause.
+func (e *UserError) Unwrap() error {
+	return e.Cause
+}
+
+// ServiceFault wraps a failure in doorkeeper itself or a dependency it calls
+// (the forge API, the network, ...). It is ours to fix, not the user's.
+type ServiceFault struct {
+	Cause error
+}
+
+// NewServiceFault wraps cause as a ServiceFault.
+func NewServiceFault(cause error) *ServiceFault {
+	return &ServiceFault{Cause: cause}
+}
+
+func (e *ServiceFault) Error() string {
+	return fmt.Sprintf("doorkeeper internal error: %s", e.Cause)
+}
+
+// Unwrap lets errors.Is/errors.As see through to Cause.
+func (e *ServiceFault) Unwrap() error {
+	return e.Cause
+}
+
+// TooManyRequestsError wraps a failure caused by the forge rate limiting
+// doorkeeper. The caller should leave the pull request's status untouched
+// and let a retry happen later.
+type TooManyRequestsError struct {
+	Cause error
+}
+
+// NewTooManyRequestsError wraps cause as a TooManyRequestsError.
+func NewTooManyRequestsError(cause error) *TooManyRequestsError {
+	return &TooManyRequestsError{Cause: cause}
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return fmt.Sprintf("rate limited: %s", e.Cause)
+}
+
+// Unwrap lets errors.Is/errors.As see through to Cause.
+func (e *TooManyRequestsError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var (
+	userErrorCount       int64
+	serviceFaultCount    int64
+	tooManyRequestsCount int64
+)
+
+// RecordUserError increments the user-error counter.
+func RecordUserError() {
+	atomic.AddInt64(&userErrorCount, 1)
+}
+
+// RecordServiceFault increments the service-fault counter.
+func RecordServiceFault() {
+	atomic.AddInt64(&serviceFaultCount, 1)
+}
+
+// RecordTooManyRequests increments the rate-limited counter.
+func RecordTooManyRequests() {
+	atomic.AddInt64(&tooManyRequestsCount, 1)
+}
+
+// WriteMetrics renders the error-class counters in Prometheus text exposition
+// format so they can be appended to an existing `/metrics` handler.
+func WriteMetrics(w io.Writer) {
+	fmt.Fprintf(w, "doorkeeper_user_errors_total %d\n", atomic.LoadInt64(&userErrorCount))
+	fmt.Fprintf(w, "doorkeeper_service_faults_total %d\n", atomic.LoadInt64(&serviceFaultCount))
+	fmt.Fprintf(w, "doorkeeper_too_many_requests_total %d\n", atomic.LoadInt64(&tooManyRequestsCount))
+}
@@ -0,0 +1,99 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// EventKind identifies which kind of webhook payload an Event carries.
+type EventKind string
+
+const (
+	EventPullRequest       EventKind = "pull_request"
+	EventPush              EventKind = "push"
+	EventPing              EventKind = "ping"
+	EventIssueComment      EventKind = "issue_comment"
+	EventPullRequestReview EventKind = "pull_request_review"
+)
+
+// Event wraps a decoded webhook payload in a forge-neutral shape. Exactly one
+// of the payload fields is set, depending on Kind; none is set for EventPing.
+type Event struct {
+	Kind         EventKind
+	PullRequest  *entity.PullRequestEvent
+	Push         *entity.PushEvent
+	IssueComment *entity.IssueCommentEvent
+	Review       *entity.PullRequestReviewEvent
+}
+
+// Forge is implemented by each source-control server doorkeeper can receive
+// webhooks from and act against (Github, GitLab, Gitea, ...).
+type Forge interface {
+	// Name identifies the forge for logging, e.g. "github".
+	Name() string
+
+	// VerifySignature checks that the request was sent by this forge's
+	// configured secret, without consuming the request body.
+	VerifySignature(r *http.Request) bool
+
+	// ParseWebhook decodes the request body into a forge-neutral Event.
+	ParseWebhook(r *http.Request) (*Event, error)
+
+	// Status reports a commit status/check for the pull request or commit at url.
+	Status(ctx context.Context, url string, status entity.GithubStatus) error
+
+	// Review posts a pull/merge request review at url.
+	Review(ctx context.Context, url string, review entity.GithubReview) error
+
+	// Comment posts a plain issue/PR comment at url.
+	Comment(ctx context.Context, url, body string) error
+
+	// FetchRuleFile fetches and parses the `.doorkeeper.yml` at url.
+	FetchRuleFile(ctx context.Context, url string) (*rule.Rule, error)
+
+	// ListCommits lists commit messages between the pull request's base and head, at url.
+	ListCommits(ctx context.Context, url string) ([]string, error)
+
+	// CreateRelease creates a release at url.
+	CreateRelease(ctx context.Context, url string, release entity.Release) error
+
+	// GetPullRequest fetches the current state of the pull/merge request at url.
+	GetPullRequest(ctx context.Context, url string) (*entity.PullRequestEvent, error)
+}
+
+// headerHint pairs the webhook event-type header name a forge uses with every
+// signature header name it may send alongside it, so Detect can tell forges
+// apart without depending on any single one's client implementation.
+type headerHint struct {
+	forge            Forge
+	eventHeader      string
+	signatureHeaders []string
+}
+
+var registry []headerHint
+
+// Register adds a Forge to the set Detect can recognize. Adapters call this
+// from an init function. A forge that accepts more than one signature header
+// (e.g. Github's legacy SHA-1 fallback) lists all of them.
+func Register(f Forge, eventHeader string, signatureHeaders ...string) {
+	registry = append(registry, headerHint{forge: f, eventHeader: eventHeader, signatureHeaders: signatureHeaders})
+}
+
+// Detect picks the Forge whose event header, and at least one signature
+// header, are present on r.
+func Detect(r *http.Request) (Forge, bool) {
+	for _, hint := range registry {
+		if r.Header.Get(hint.eventHeader) == "" {
+			continue
+		}
+		for _, signatureHeader := range hint.signatureHeaders {
+			if r.Header.Get(signatureHeader) != "" {
+				return hint.forge, true
+			}
+		}
+	}
+	return nil, false
+}
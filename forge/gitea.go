@@ -0,0 +1,251 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+const (
+	giteaEventHeader     = "X-Gitea-Event"
+	giteaSignatureHeader = "X-Gitea-Signature"
+)
+
+func init() {
+	Register(NewGitea(http.DefaultClient), giteaEventHeader, giteaSignatureHeader)
+}
+
+// Gitea adapts a self-hosted Gitea instance to the Forge interface.
+type Gitea struct {
+	client *http.Client
+}
+
+// NewGitea creates a Gitea forge adapter using httpClient for API calls.
+func NewGitea(httpClient *http.Client) *Gitea {
+	return &Gitea{client: httpClient}
+}
+
+// Name implements Forge.
+func (g *Gitea) Name() string {
+	return "gitea"
+}
+
+// VerifySignature implements Forge. Gitea HMAC-SHA256-signs the raw body,
+// same as Github, but without a "sha256=" prefix on the header value.
+func (g *Gitea) VerifySignature(r *http.Request) bool {
+	return verifyHMACSHA256(r, giteaSignatureHeader, "")
+}
+
+// ParseWebhook implements Forge.
+func (g *Gitea) ParseWebhook(r *http.Request) (*Event, error) {
+	buf := new(bytes.Buffer)
+	io.Copy(buf, r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+
+	switch r.Header.Get(giteaEventHeader) {
+	case "pull_request":
+		var payload giteaPullRequestEvent
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode gitea pull_request event: %w", err)
+		}
+		return &Event{Kind: EventPullRequest, PullRequest: payload.toEntity()}, nil
+	case "push":
+		var payload giteaPushEvent
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode gitea push event: %w", err)
+		}
+		return &Event{Kind: EventPush, Push: payload.toEntity()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gitea event %q", r.Header.Get(giteaEventHeader))
+	}
+}
+
+// Status implements Forge.
+func (g *Gitea) Status(ctx context.Context, url string, status entity.GithubStatus) error {
+	return postJSON(ctx, g.client, url, giteaStatus{State: status.Status, Context: status.Context, Description: status.Description})
+}
+
+// Review implements Forge.
+func (g *Gitea) Review(ctx context.Context, url string, review entity.GithubReview) error {
+	return postJSON(ctx, g.client, url, giteaReview{Body: review.Body, Event: review.Event})
+}
+
+// Comment implements Forge.
+func (g *Gitea) Comment(ctx context.Context, url, body string) error {
+	return postJSON(ctx, g.client, url, giteaComment{Body: body})
+}
+
+// FetchRuleFile implements Forge.
+func (g *Gitea) FetchRuleFile(ctx context.Context, url string) (*rule.Rule, error) {
+	return fetchYAMLRule(ctx, g.client, url)
+}
+
+// ListCommits implements Forge.
+func (g *Gitea) ListCommits(ctx context.Context, url string) ([]string, error) {
+	var commits []giteaCommit
+	if err := getJSON(ctx, g.client, url, &commits); err != nil {
+		return nil, fmt.Errorf("failed to list gitea commits: %w", err)
+	}
+	messages := make([]string, 0, len(commits))
+	for _, c := range commits {
+		messages = append(messages, c.Commit.Message)
+	}
+	return messages, nil
+}
+
+// giteaBaseURL returns the configured base URL of the self-hosted Gitea
+// instance (e.g. "https://git.example.com"), used to turn the bare API paths
+// Gitea's webhook payloads carry into requestable URLs.
+func giteaBaseURL() string {
+	return os.Getenv("GITEA_BASE_URL")
+}
+
+// CreateRelease implements Forge.
+func (g *Gitea) CreateRelease(ctx context.Context, url string, release entity.Release) error {
+	return postJSON(ctx, g.client, url, giteaRelease{TagName: release.TagName, Name: release.Name, Body: release.Body})
+}
+
+// GetPullRequest implements Forge.
+func (g *Gitea) GetPullRequest(ctx context.Context, url string) (*entity.PullRequestEvent, error) {
+	var pr struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Body   string `json:"body"`
+		Base   struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"base_repo"`
+	}
+	if err := getJSON(ctx, g.client, url, &pr); err != nil {
+		return nil, fmt.Errorf("failed to fetch gitea pull request: %w", err)
+	}
+
+	base := giteaBaseURL() + fmt.Sprintf("/api/v1/repos/%s", pr.Repository.FullName)
+	return &entity.PullRequestEvent{
+		Number: pr.Number,
+		PullRequest: entity.PullRequest{
+			Title:       pr.Title,
+			Body:        pr.Body,
+			Base:        entity.PullRequestRef{Ref: pr.Base.Ref},
+			Head:        entity.PullRequestRef{Ref: pr.Head.Ref},
+			URL:         url,
+			CommitsURL:  fmt.Sprintf("%s/pulls/%d/commits", base, pr.Number),
+			CommentsURL: fmt.Sprintf("%s/issues/%d/comments", base, pr.Number),
+		},
+		Repository: entity.Repository{
+			FullName:    pr.Repository.FullName,
+			ContentsURL: base + "/raw",
+			StatusesURL: base + "/statuses",
+			ReleasesURL: base + "/releases",
+		},
+	}, nil
+}
+
+type giteaPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Base  struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (e giteaPullRequestEvent) toEntity() *entity.PullRequestEvent {
+	base := giteaBaseURL() + fmt.Sprintf("/api/v1/repos/%s", e.Repository.FullName)
+	return &entity.PullRequestEvent{
+		Action: e.Action,
+		Number: e.Number,
+		PullRequest: entity.PullRequest{
+			Title:       e.PullRequest.Title,
+			Body:        e.PullRequest.Body,
+			Base:        entity.PullRequestRef{Ref: e.PullRequest.Base.Ref},
+			Head:        entity.PullRequestRef{Ref: e.PullRequest.Head.Ref},
+			URL:         fmt.Sprintf("%s/pulls/%d", base, e.Number),
+			CommitsURL:  fmt.Sprintf("%s/pulls/%d/commits", base, e.Number),
+			CommentsURL: fmt.Sprintf("%s/issues/%d/comments", base, e.Number),
+		},
+		Repository: entity.Repository{
+			FullName:    e.Repository.FullName,
+			ContentsURL: base + "/raw",
+			StatusesURL: base + "/statuses",
+			ReleasesURL: base + "/releases",
+		},
+	}
+}
+
+type giteaPushEvent struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Commits []struct {
+		Message string `json:"message"`
+	} `json:"commits"`
+}
+
+func (e giteaPushEvent) toEntity() *entity.PushEvent {
+	base := giteaBaseURL() + fmt.Sprintf("/api/v1/repos/%s", e.Repository.FullName)
+	commits := make([]entity.PushCommit, 0, len(e.Commits))
+	for _, c := range e.Commits {
+		commits = append(commits, entity.PushCommit{Message: c.Message})
+	}
+	return &entity.PushEvent{
+		Ref: e.Ref,
+		Repository: entity.Repository{
+			FullName:    e.Repository.FullName,
+			ContentsURL: base + "/raw",
+			StatusesURL: base + "/statuses",
+			ReleasesURL: base + "/releases",
+		},
+		Commits: commits,
+	}
+}
+
+type giteaStatus struct {
+	State       string `json:"state"`
+	Context     string `json:"context"`
+	Description string `json:"description"`
+}
+
+type giteaReview struct {
+	Body  string `json:"body"`
+	Event string `json:"event"`
+}
+
+type giteaComment struct {
+	Body string `json:"body"`
+}
+
+type giteaRelease struct {
+	TagName string `json:"tag_name"`
+	Name    string `json:"name"`
+	Body    string `json:"body"`
+}
+
+type giteaCommit struct {
+	Commit struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+}
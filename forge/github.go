@@ -0,0 +1,129 @@
+package forge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/github"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+const (
+	githubEventHeader     = "X-Github-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	// legacyGithubSignatureHeader is sent instead of githubSignatureHeader by
+	// older GitHub Enterprise deployments that predate SHA-256 signing.
+	legacyGithubSignatureHeader = "X-Hub-Signature"
+)
+
+func init() {
+	Register(NewGithub(github.DefaultClient), githubEventHeader, githubSignatureHeader, legacyGithubSignatureHeader)
+}
+
+// Github adapts github.Client to the Forge interface.
+type Github struct {
+	client *github.Client
+}
+
+// NewGithub wraps an existing github.Client as a Forge.
+func NewGithub(c *github.Client) *Github {
+	return &Github{client: c}
+}
+
+// Name implements Forge.
+func (g *Github) Name() string {
+	return "github"
+}
+
+// VerifySignature implements Forge. It prefers the SHA-256 signature, and
+// falls back to the legacy SHA-1 one for older GitHub Enterprise instances
+// that only send X-Hub-Signature.
+func (g *Github) VerifySignature(r *http.Request) bool {
+	if r.Header.Get(githubSignatureHeader) != "" {
+		return verifyHMACSHA256(r, githubSignatureHeader, "sha256=")
+	}
+	if r.Header.Get(legacyGithubSignatureHeader) != "" {
+		return compareLegacySHA1(r)
+	}
+	return false
+}
+
+// ParseWebhook implements Forge.
+func (g *Github) ParseWebhook(r *http.Request) (*Event, error) {
+	switch r.Header.Get(githubEventHeader) {
+	case "pull_request":
+		var evt entity.PullRequestEvent
+		if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
+			return nil, fmt.Errorf("failed to decode github pull_request event: %w", err)
+		}
+		return &Event{Kind: EventPullRequest, PullRequest: &evt}, nil
+	case "push":
+		var evt entity.PushEvent
+		if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
+			return nil, fmt.Errorf("failed to decode github push event: %w", err)
+		}
+		return &Event{Kind: EventPush, Push: &evt}, nil
+	case "ping":
+		return &Event{Kind: EventPing}, nil
+	case "issue_comment":
+		var evt entity.IssueCommentEvent
+		if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
+			return nil, fmt.Errorf("failed to decode github issue_comment event: %w", err)
+		}
+		return &Event{Kind: EventIssueComment, IssueComment: &evt}, nil
+	case "pull_request_review":
+		var evt entity.PullRequestReviewEvent
+		if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
+			return nil, fmt.Errorf("failed to decode github pull_request_review event: %w", err)
+		}
+		return &Event{Kind: EventPullRequestReview, Review: &evt}, nil
+	default:
+		return nil, fmt.Errorf("unsupported github event %q", r.Header.Get(githubEventHeader))
+	}
+}
+
+// Status implements Forge.
+func (g *Github) Status(ctx context.Context, url string, status entity.GithubStatus) error {
+	return g.client.Status(ctx, url, status)
+}
+
+// Review implements Forge.
+func (g *Github) Review(ctx context.Context, url string, review entity.GithubReview) error {
+	return g.client.Review(ctx, url, review)
+}
+
+// Comment implements Forge.
+func (g *Github) Comment(ctx context.Context, url, body string) error {
+	return g.client.Comment(ctx, url, body)
+}
+
+// FetchRuleFile implements Forge.
+func (g *Github) FetchRuleFile(ctx context.Context, url string) (*rule.Rule, error) {
+	return g.client.RuleFile(ctx, url)
+}
+
+// ListCommits implements Forge.
+func (g *Github) ListCommits(ctx context.Context, url string) ([]string, error) {
+	return g.client.ListCommits(ctx, url)
+}
+
+// CreateRelease implements Forge.
+func (g *Github) CreateRelease(ctx context.Context, url string, release entity.Release) error {
+	return g.client.CreateRelease(ctx, url, release)
+}
+
+// GetPullRequest implements Forge.
+func (g *Github) GetPullRequest(ctx context.Context, url string) (*entity.PullRequestEvent, error) {
+	return g.client.GetPullRequest(ctx, url)
+}
+
+// verifyHMACSHA256 recomputes the HMAC-SHA256 of the request body (buffering
+// and rewinding it for later readers) and compares it in constant time
+// against header, whose value carries prefix (e.g. "sha256=").
+func verifyHMACSHA256(r *http.Request, header, prefix string) bool {
+	return compareHMAC(r, header, prefix, sha256.New)
+}
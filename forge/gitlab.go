@@ -0,0 +1,312 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+const (
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabSignatureHeader = "X-Gitlab-Token"
+)
+
+func init() {
+	Register(NewGitlab(http.DefaultClient), gitlabEventHeader, gitlabSignatureHeader)
+}
+
+// Gitlab adapts a self-hosted or gitlab.com instance to the Forge interface.
+type Gitlab struct {
+	client *http.Client
+}
+
+// NewGitlab creates a Gitlab forge adapter using httpClient for API calls.
+func NewGitlab(httpClient *http.Client) *Gitlab {
+	return &Gitlab{client: httpClient}
+}
+
+// Name implements Forge.
+func (g *Gitlab) Name() string {
+	return "gitlab"
+}
+
+// VerifySignature implements Forge. GitLab sends the configured secret
+// directly in X-Gitlab-Token rather than signing the body. GITLAB_WEBHOOK_SECRET
+// may hold a comma/space-separated list so a secret can be rotated without
+// downtime.
+func (g *Gitlab) VerifySignature(r *http.Request) bool {
+	got := []byte(r.Header.Get(gitlabSignatureHeader))
+	for i, token := range splitSecrets(os.Getenv("GITLAB_WEBHOOK_SECRET")) {
+		if subtle.ConstantTimeCompare([]byte(token), got) == 1 {
+			debugLog("%s matched webhook secret #%d", gitlabSignatureHeader, i)
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWebhook implements Forge.
+func (g *Gitlab) ParseWebhook(r *http.Request) (*Event, error) {
+	buf := new(bytes.Buffer)
+	io.Copy(buf, r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+
+	switch r.Header.Get(gitlabEventHeader) {
+	case "Merge Request Hook":
+		var payload gitlabMergeRequestEvent
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode gitlab merge request event: %w", err)
+		}
+		return &Event{Kind: EventPullRequest, PullRequest: payload.toEntity()}, nil
+	case "Push Hook", "Tag Push Hook":
+		var payload gitlabPushEvent
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode gitlab push event: %w", err)
+		}
+		return &Event{Kind: EventPush, Push: payload.toEntity()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gitlab event %q", r.Header.Get(gitlabEventHeader))
+	}
+}
+
+// Status implements Forge.
+func (g *Gitlab) Status(ctx context.Context, url string, status entity.GithubStatus) error {
+	return postJSON(ctx, g.client, url, gitlabStatus{State: gitlabState(status.Status), Description: status.Description})
+}
+
+// Review implements Forge. GitLab has no separate review endpoint, so the
+// review is posted as a note to the merge request's notes URL, the same one
+// CommentURL returns.
+func (g *Gitlab) Review(ctx context.Context, url string, review entity.GithubReview) error {
+	return postJSON(ctx, g.client, strings.TrimSuffix(url, "/reviews")+"/notes", gitlabNote{Body: review.Body})
+}
+
+// Comment implements Forge.
+func (g *Gitlab) Comment(ctx context.Context, url, body string) error {
+	return postJSON(ctx, g.client, url, gitlabNote{Body: body})
+}
+
+// FetchRuleFile implements Forge.
+func (g *Gitlab) FetchRuleFile(ctx context.Context, url string) (*rule.Rule, error) {
+	return fetchYAMLRule(ctx, g.client, url)
+}
+
+// ListCommits implements Forge.
+func (g *Gitlab) ListCommits(ctx context.Context, url string) ([]string, error) {
+	var commits []gitlabCommit
+	if err := getJSON(ctx, g.client, url, &commits); err != nil {
+		return nil, fmt.Errorf("failed to list gitlab commits: %w", err)
+	}
+	messages := make([]string, 0, len(commits))
+	for _, c := range commits {
+		messages = append(messages, c.Message)
+	}
+	return messages, nil
+}
+
+// CreateRelease implements Forge.
+func (g *Gitlab) CreateRelease(ctx context.Context, url string, release entity.Release) error {
+	return postJSON(ctx, g.client, url, gitlabRelease{TagName: release.TagName, Name: release.Name, Description: release.Body})
+}
+
+// GetPullRequest implements Forge.
+func (g *Gitlab) GetPullRequest(ctx context.Context, url string) (*entity.PullRequestEvent, error) {
+	var mr struct {
+		Iid          int    `json:"iid"`
+		ProjectID    int    `json:"project_id"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		TargetBranch string `json:"target_branch"`
+		SourceBranch string `json:"source_branch"`
+		WebURL       string `json:"web_url"`
+	}
+	if err := getJSON(ctx, g.client, url, &mr); err != nil {
+		return nil, fmt.Errorf("failed to fetch gitlab merge request: %w", err)
+	}
+
+	apiBase := gitlabProjectAPIBase(mr.WebURL, mr.ProjectID)
+	fullName, projectWebURL := gitlabProjectFromMRWebURL(mr.WebURL)
+	return &entity.PullRequestEvent{
+		Number: mr.Iid,
+		PullRequest: entity.PullRequest{
+			Title:       mr.Title,
+			Body:        mr.Description,
+			Base:        entity.PullRequestRef{Ref: mr.TargetBranch},
+			Head:        entity.PullRequestRef{Ref: mr.SourceBranch},
+			URL:         url,
+			CommitsURL:  fmt.Sprintf("%s/merge_requests/%d/commits", apiBase, mr.Iid),
+			CommentsURL: fmt.Sprintf("%s/merge_requests/%d/notes", apiBase, mr.Iid),
+		},
+		Repository: entity.Repository{
+			FullName:    fullName,
+			ContentsURL: projectWebURL + "/-/raw/HEAD",
+			StatusesURL: projectWebURL + "/statuses",
+			ReleasesURL: apiBase + "/releases",
+		},
+	}, nil
+}
+
+// gitlabProjectFromMRWebURL derives the project's full path and web URL from
+// a merge request's own web_url, since a single-MR GET response doesn't
+// otherwise carry the project's identifying fields the way the webhook
+// payload's nested "project" object does.
+func gitlabProjectFromMRWebURL(mrWebURL string) (fullName, webURL string) {
+	u, err := url.Parse(mrWebURL)
+	if err != nil {
+		return "", ""
+	}
+	path := strings.Trim(u.Path, "/")
+	if i := strings.Index(path, "/-/merge_requests/"); i >= 0 {
+		path = path[:i]
+	}
+	return path, fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, path)
+}
+
+// gitlabState maps a doorkeeper commit status to GitLab's own vocabulary.
+func gitlabState(status string) string {
+	switch status {
+	case "success":
+		return "success"
+	case "failure":
+		return "failed"
+	case "error":
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+type gitlabMergeRequestEvent struct {
+	ObjectAttributes struct {
+		Action       string `json:"action"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		Iid          int    `json:"iid"`
+		TargetBranch string `json:"target_branch"`
+		SourceBranch string `json:"source_branch"`
+		// Oldrev is only set on an "update" action when new commits landed,
+		// distinguishing that from a metadata-only edit (title/description).
+		Oldrev string `json:"oldrev"`
+	} `json:"object_attributes"`
+	Project struct {
+		ID                int    `json:"id"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+}
+
+func (e gitlabMergeRequestEvent) toEntity() *entity.PullRequestEvent {
+	apiBase := gitlabProjectAPIBase(e.Project.WebURL, e.Project.ID)
+	return &entity.PullRequestEvent{
+		Action: gitlabAction(e.ObjectAttributes.Action, e.ObjectAttributes.Oldrev),
+		Number: e.ObjectAttributes.Iid,
+		PullRequest: entity.PullRequest{
+			Title:       e.ObjectAttributes.Title,
+			Body:        e.ObjectAttributes.Description,
+			Base:        entity.PullRequestRef{Ref: e.ObjectAttributes.TargetBranch},
+			Head:        entity.PullRequestRef{Ref: e.ObjectAttributes.SourceBranch},
+			URL:         fmt.Sprintf("%s/merge_requests/%d", apiBase, e.ObjectAttributes.Iid),
+			CommitsURL:  fmt.Sprintf("%s/merge_requests/%d/commits", apiBase, e.ObjectAttributes.Iid),
+			CommentsURL: fmt.Sprintf("%s/merge_requests/%d/notes", apiBase, e.ObjectAttributes.Iid),
+		},
+		Repository: entity.Repository{
+			FullName:    e.Project.PathWithNamespace,
+			ContentsURL: e.Project.WebURL + "/-/raw/HEAD",
+			StatusesURL: e.Project.WebURL + "/statuses",
+		},
+	}
+}
+
+// gitlabAction maps GitLab's merge request action vocabulary onto the
+// Github-derived one the plugins switch on, so the same plugins drive all
+// forges instead of silently no-oping for GitLab events. GitLab fires
+// "update" both for a metadata edit (title/description) and for new commits
+// landing; oldrev is only set in the latter case, so it's what distinguishes
+// Github's "edited" from "synchronize".
+func gitlabAction(action, oldrev string) string {
+	switch action {
+	case "open", "reopen":
+		return "opened"
+	case "update":
+		if oldrev != "" {
+			return "synchronize"
+		}
+		return "edited"
+	case "close":
+		return "closed"
+	case "merge":
+		return "closed"
+	default:
+		return action
+	}
+}
+
+// gitlabProjectAPIBase builds the REST API base URL for a project from its
+// web URL's scheme/host, since the webhook payload only carries the latter.
+func gitlabProjectAPIBase(webURL string, id int) string {
+	u, err := url.Parse(webURL)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("https://gitlab.com/api/v4/projects/%d", id)
+	}
+	return fmt.Sprintf("%s://%s/api/v4/projects/%d", u.Scheme, u.Host, id)
+}
+
+type gitlabPushEvent struct {
+	Ref     string `json:"ref"`
+	Project struct {
+		ID                int    `json:"id"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+	Commits []struct {
+		Message string `json:"message"`
+	} `json:"commits"`
+}
+
+func (e gitlabPushEvent) toEntity() *entity.PushEvent {
+	apiBase := gitlabProjectAPIBase(e.Project.WebURL, e.Project.ID)
+	commits := make([]entity.PushCommit, 0, len(e.Commits))
+	for _, c := range e.Commits {
+		commits = append(commits, entity.PushCommit{Message: c.Message})
+	}
+	return &entity.PushEvent{
+		Ref: e.Ref,
+		Repository: entity.Repository{
+			FullName:    e.Project.PathWithNamespace,
+			ContentsURL: e.Project.WebURL + "/-/raw/HEAD",
+			StatusesURL: e.Project.WebURL + "/statuses",
+			ReleasesURL: apiBase + "/releases",
+		},
+		Commits: commits,
+	}
+}
+
+type gitlabStatus struct {
+	State       string `json:"state"`
+	Description string `json:"description"`
+}
+
+type gitlabNote struct {
+	Body string `json:"body"`
+}
+
+type gitlabRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type gitlabCommit struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,85 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// postJSON marshals body and POSTs it to url via client, treating any
+// non-2xx response as an error. Shared by forges whose API is a plain JSON
+// REST interface (GitLab, Gitea).
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// getJSON GETs url via client and decodes the JSON response body into out,
+// treating any non-2xx response as an error. Shared by forges whose API is a
+// plain JSON REST interface (GitLab, Gitea).
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// fetchYAMLRule fetches and parses the `.doorkeeper.yml` at url via client.
+func fetchYAMLRule(ctx context.Context, client *http.Client, url string) (*rule.Rule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make rule file request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rule file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rule file request returned status %d", resp.StatusCode)
+	}
+
+	var r rule.Rule
+	if err := yaml.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("failed to parse rule file: %w", err)
+	}
+	return &r, nil
+}
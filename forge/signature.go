@@ -0,0 +1,85 @@
+package forge
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// compareHMAC reads and rewinds r.Body, computes the HMAC of its bytes with
+// each secret configured in env using newHash, and compares it in constant
+// time against the header value (after stripping prefix, e.g. "sha256=").
+// env may hold a comma/space-separated list of secrets so an old and a new
+// secret can both be accepted while it's being rotated.
+func compareHMAC(r *http.Request, header, prefix string, newHash func() hash.Hash) bool {
+	buf := new(bytes.Buffer)
+	io.Copy(buf, r.Body)
+	defer func() {
+		r.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+	}()
+
+	got := []byte(r.Header.Get(header))
+	for i, secret := range splitSecrets(os.Getenv(secretEnvFor(header))) {
+		mac := hmac.New(newHash, []byte(secret))
+		mac.Write(buf.Bytes())
+		expected := prefix + fmt.Sprintf("%x", mac.Sum(nil))
+		if hmac.Equal([]byte(expected), got) {
+			debugLog("%s matched webhook secret #%d", header, i)
+			return true
+		}
+	}
+	return false
+}
+
+// splitSecrets parses a WEBHOOK_SECRET(S) env value into its candidate
+// secrets, accepting either commas or whitespace as separators so operators
+// can rotate secrets by adding a new one alongside the old rather than
+// replacing it in place.
+func splitSecrets(raw string) []string {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	if len(fields) == 0 {
+		return []string{""}
+	}
+	return fields
+}
+
+// debugLog logs msg only when DOORKEEPER_DEBUG is set, so operators can turn
+// on secret-rotation matching info without it showing up in normal logs.
+func debugLog(format string, args ...interface{}) {
+	if os.Getenv("DOORKEEPER_DEBUG") == "" {
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// compareLegacySHA1 is the same as compareHMAC but for the legacy
+// "X-Hub-Signature" header (SHA-1, "sha1=" prefix) that older GitHub
+// Enterprise deployments send instead of X-Hub-Signature-256.
+func compareLegacySHA1(r *http.Request) bool {
+	return compareHMAC(r, legacyGithubSignatureHeader, "sha1=", sha1.New)
+}
+
+// secretEnvFor maps a signature header to the environment variable that
+// holds its forge's webhook secret(s).
+func secretEnvFor(header string) string {
+	switch header {
+	case githubSignatureHeader, legacyGithubSignatureHeader:
+		return "WEBHOOK_SECRETS"
+	case gitlabSignatureHeader:
+		return "GITLAB_WEBHOOK_SECRET"
+	case giteaSignatureHeader:
+		return "GITEA_WEBHOOK_SECRET"
+	default:
+		return "WEBHOOK_SECRETS"
+	}
+}
@@ -0,0 +1,57 @@
+package forge
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func signBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + fmt.Sprintf("%x", mac.Sum(nil))
+}
+
+func newSignedRequest(t *testing.T, body []byte, header, signature string) *http.Request {
+	t.Helper()
+	r, err := http.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	r.Header.Set(header, signature)
+	return r
+}
+
+func TestCompareHMAC_MatchesAnyConfiguredSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_SECRETS", "old-secret, new-secret")
+
+	body := []byte(`{"hello":"world"}`)
+	r := newSignedRequest(t, body, githubSignatureHeader, signBody(body, "new-secret"))
+
+	if !compareHMAC(r, githubSignatureHeader, "sha256=", sha256.New) {
+		t.Fatal("compareHMAC() = false for a body signed with the second configured secret, want true")
+	}
+
+	got, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("failed to read rewound body: %v", err)
+	}
+	if !bytes.Equal(got, body) {
+		t.Fatalf("body after compareHMAC() = %q, want %q: body must be rewound for later handlers", got, body)
+	}
+}
+
+func TestCompareHMAC_RejectsSignatureFromUnconfiguredSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_SECRETS", "old-secret, new-secret")
+
+	body := []byte(`{"hello":"world"}`)
+	r := newSignedRequest(t, body, githubSignatureHeader, signBody(body, "wrong-secret"))
+
+	if compareHMAC(r, githubSignatureHeader, "sha256=", sha256.New) {
+		t.Fatal("compareHMAC() = true for a signature from an unconfigured secret, want false")
+	}
+}
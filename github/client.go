@@ -0,0 +1,207 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	dkerrors "github.com/ysugimoto/doorkeeper/errors"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// Deprecated: use rule.SettingFile.
+const SettingFile = rule.SettingFile
+
+// Client talks to the Github API on behalf of doorkeeper.
+type Client struct {
+	token      string
+	httpClient *http.Client
+}
+
+// DefaultClient is constructed from the GITHUB_TOKEN environment variable.
+var DefaultClient = New(os.Getenv("GITHUB_TOKEN"))
+
+// New creates a Client authenticated with token.
+func New(token string) *Client {
+	return &Client{
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// RuleFile fetches and parses the `.doorkeeper.yml` at url.
+func (c *Client) RuleFile(ctx context.Context, url string) (*rule.Rule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to make rule file request: %w", err))
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to fetch rule file: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if err := classifyStatus(url, resp.StatusCode); err != nil {
+		return nil, err
+	}
+
+	var r rule.Rule
+	if err := yaml.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to parse rule file: %w", err))
+	}
+	return &r, nil
+}
+
+// Status posts a commit status to url.
+func (c *Client) Status(ctx context.Context, url string, status entity.GithubStatus) error {
+	return c.post(ctx, url, status)
+}
+
+// Review posts a pull request review to url.
+func (c *Client) Review(ctx context.Context, url string, review entity.GithubReview) error {
+	return c.post(ctx, url, review)
+}
+
+// Comment posts a plain issue/PR comment to url.
+func (c *Client) Comment(ctx context.Context, url, body string) error {
+	return c.post(ctx, url, entity.Comment{Body: body})
+}
+
+// ListCommits lists the commit messages at url (a pull request's commits_url).
+func (c *Client) ListCommits(ctx context.Context, url string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to make commits request: %w", err))
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to list commits: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if err := classifyStatus(url, resp.StatusCode); err != nil {
+		return nil, err
+	}
+
+	var commits []struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to parse commits response: %w", err))
+	}
+	messages := make([]string, 0, len(commits))
+	for _, c := range commits {
+		messages = append(messages, c.Commit.Message)
+	}
+	return messages, nil
+}
+
+// CreateRelease creates a release at url.
+func (c *Client) CreateRelease(ctx context.Context, url string, release entity.Release) error {
+	return c.post(ctx, url, release)
+}
+
+// GetPullRequest fetches the current state of the pull request at url.
+func (c *Client) GetPullRequest(ctx context.Context, url string) (*entity.PullRequestEvent, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to make pull request request: %w", err))
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to fetch pull request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if err := classifyStatus(url, resp.StatusCode); err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Number      int    `json:"number"`
+		Title       string `json:"title"`
+		Body        string `json:"body"`
+		URL         string `json:"url"`
+		CommitsURL  string `json:"commits_url"`
+		CommentsURL string `json:"comments_url"`
+		Base        struct {
+			Ref  string            `json:"ref"`
+			Repo entity.Repository `json:"repo"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, dkerrors.NewServiceFault(fmt.Errorf("failed to parse pull request response: %w", err))
+	}
+
+	return &entity.PullRequestEvent{
+		Number: payload.Number,
+		PullRequest: entity.PullRequest{
+			Title:       payload.Title,
+			Body:        payload.Body,
+			Base:        entity.PullRequestRef{Ref: payload.Base.Ref},
+			Head:        entity.PullRequestRef{Ref: payload.Head.Ref},
+			URL:         payload.URL,
+			CommitsURL:  payload.CommitsURL,
+			CommentsURL: payload.CommentsURL,
+		},
+		Repository: payload.Base.Repo,
+	}, nil
+}
+
+func (c *Client) post(ctx context.Context, url string, body interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return dkerrors.NewServiceFault(fmt.Errorf("failed to marshal request body: %w", err))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return dkerrors.NewServiceFault(fmt.Errorf("failed to make request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return dkerrors.NewServiceFault(fmt.Errorf("failed to send request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	return classifyStatus(url, resp.StatusCode)
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	}
+}
+
+// classifyStatus turns a non-2xx response into the right error class: a 429
+// means the caller should back off and retry, anything else in the 4xx/5xx
+// range is a doorkeeper-side fault against the Github API.
+func classifyStatus(url string, statusCode int) error {
+	switch {
+	case statusCode < 300:
+		return nil
+	case statusCode == http.StatusTooManyRequests:
+		return dkerrors.NewTooManyRequestsError(fmt.Errorf("request to %s returned status %d", url, statusCode))
+	default:
+		return dkerrors.NewServiceFault(fmt.Errorf("request to %s returned status %d", url, statusCode))
+	}
+}
@@ -1,38 +1,31 @@
 package handler
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"net/http"
 	"strings"
 	"time"
 
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/json"
-	"io/ioutil"
-	"net/http"
-
-	"github.com/ysugimoto/doorkeeper/entity"
-	"github.com/ysugimoto/doorkeeper/github"
+	"github.com/ysugimoto/doorkeeper/dispatcher"
+	"github.com/ysugimoto/doorkeeper/forge"
+	"github.com/ysugimoto/doorkeeper/plugin"
 	"github.com/ysugimoto/doorkeeper/rule"
 )
 
-const (
-	githubEventNamePullRequest = "pull_request"
-	githubEventNamePing        = "ping"
-	githubEventNamePush        = "push"
-
-	githubPullRequestActionOpened      = "opened"
-	githubPullRequestActionEdited      = "edited"
-	githubPullRequestActionSynchronize = "synchronize"
-)
-
-func WebhookHandler(prefix string, c *github.Client) http.Handler {
-	if c == nil {
-		c = github.DefaultClient
+// DefaultDispatcher runs plugin handlers for deployments that don't build
+// their own Dispatcher.
+var DefaultDispatcher = dispatcher.New(8, 256, dispatcher.NewRateLimiter(60, time.Minute))
+
+// WebhookHandler is a thin dispatcher: it identifies the forge a webhook
+// came from, decodes it to a forge-neutral Event, and fans that event out to
+// every plugin enabled for the repository. All actual behavior (validation,
+// release notes, tag releases, slash commands, ...) lives in the plugin
+// package, so adding a new capability never requires editing this function.
+func WebhookHandler(prefix string, d *dispatcher.Dispatcher) http.Handler {
+	if d == nil {
+		d = DefaultDispatcher
 	}
 
 	return http.StripPrefix(
@@ -43,145 +36,109 @@ func WebhookHandler(prefix string, c *github.Client) http.Handler {
 				return
 			}
 
-			// Check webhook request comes from exact Github server
-			if !compareSignature(r) {
+			f, ok := forge.Detect(r)
+			if !ok {
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, "We don't recognize which forge this webhook came from")
+				return
+			}
+
+			// Check webhook request comes from the forge it claims to
+			if !f.VerifySignature(r) {
 				w.WriteHeader(http.StatusBadRequest)
 				io.WriteString(w, "Signature unmatched")
 				return
 			}
 
-			// Switch action by header
-			switch r.Header.Get("X-Github-Event") {
-			case githubEventNamePullRequest:
-				// Accept PullRequest event
-				var evt entity.GithubPullRequestEvent
-				if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
-					w.WriteHeader(http.StatusBadRequest)
-					io.WriteString(w, "Failed to decode github webhook body to JSON "+err.Error())
-					return
-				}
-
-				// Get and parse rule from destination repository
-				rr, err := c.RuleFile(r.Context(), evt.ContentURL(github.SettingFile))
-				if err != nil {
-					rr = rule.DefaultRule
-				}
-
-				// switch actions by action
-				switch evt.Action {
-
-				// When new pullrequest has been opened, run validate and factory relates note
-				case githubPullRequestActionOpened:
-					if !rr.Validation.Disable {
-						go validatePullRequest(c, evt, rr)
-					}
-					if ok, _ := rr.MatchValidateBranch(evt.BaseBranch()); ok {
-						if !rr.ReleaseNote.Disable {
-							go factoryRelaseNotes(c, evt, rr)
-						}
-					}
-
-				// When pullrequest has been edited, only runs validate
-				case githubPullRequestActionEdited:
-					if !rr.Validation.Disable {
-						go validatePullRequest(c, evt, rr)
-					}
-
-				// When pullrequest has been synchronized, only runs factory release notes
-				case githubPullRequestActionSynchronize:
-					if ok, _ := rr.MatchReleaseNoteBranch(evt.BaseBranch()); ok {
-						if !rr.ReleaseNote.Disable {
-							go factoryRelaseNotes(c, evt, rr)
-						}
-					}
-				}
-				successResponse(w)
+			evt, err := f.ParseWebhook(r)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, "Failed to decode "+f.Name()+" webhook body to JSON "+err.Error())
 				return
+			}
 
-			case githubEventNamePush:
-				// Accept push event
-				var evt entity.GithubPushEvent
-				if err := json.NewDecoder(r.Body).Decode(&evt); err != nil {
-					w.WriteHeader(http.StatusBadRequest)
-					io.WriteString(w, "Failed to decode github webhook body to JSON "+err.Error())
-					return
-				}
-
-				// Get and parse rule from destination repository
-				rr, err := c.RuleFile(r.Context(), evt.ContentURL("/.doorkeeper.yml"))
-				if err != nil {
-					rr = rule.DefaultRule
-				}
-
-				switch {
-				case strings.HasPrefix(evt.Ref, "refs/tags"):
-					if ok, _ := rr.MatchTag(strings.TrimPrefix(evt.Ref, "refs/tags/")); ok {
-						if !rr.ReleaseNote.Disable {
-							go processTagPushEvent(c, evt, rr)
-						}
-					}
-				}
+			if evt.Kind == forge.EventPing {
 				successResponse(w)
 				return
+			}
 
-			case githubEventNamePing:
-				// Accept Ping event
-				successResponse(w)
+			rr, err := fetchRule(r.Context(), f, evt)
+			if err != nil {
+				rr = rule.DefaultRule
+			}
+
+			if err := dispatch(d, f, evt, rr); err != nil {
+				// Ask the forge to retry later instead of dropping the event
+				w.WriteHeader(http.StatusTooManyRequests)
+				io.WriteString(w, err.Error())
 				return
 			}
 
-			// Forbid other events
-			w.WriteHeader(http.StatusForbidden)
-			io.WriteString(w, "We don't support event of '"+r.Header.Get("X-Github-Event")+"'")
+			successResponse(w)
 		}),
 	)
 }
 
-func successResponse(w http.ResponseWriter) {
-	message := "Accepted"
-
-	w.Header().Set("Content-Type", "text/plain")
-	w.Header().Set("Content-Length", fmt.Sprint(len(message)))
-	w.WriteHeader(http.StatusOK)
-	io.WriteString(w, message)
+// fetchRule resolves the repository's `.doorkeeper.yml` from whichever
+// payload field evt carries.
+func fetchRule(ctx context.Context, f forge.Forge, evt *forge.Event) (*rule.Rule, error) {
+	switch evt.Kind {
+	case forge.EventPullRequest:
+		return f.FetchRuleFile(ctx, evt.PullRequest.ContentURL(rule.SettingFile))
+	case forge.EventPush:
+		return f.FetchRuleFile(ctx, evt.Push.ContentURL(rule.SettingFile))
+	default:
+		return rule.DefaultRule, nil
+	}
 }
 
-// compares webhook request signature with secret
-func compareSignature(r *http.Request) bool {
-	buf := new(bytes.Buffer)
-	io.Copy(buf, r.Body)
-
-	// Rewind request body
-	defer func() {
-		r.Body = ioutil.NopCloser(buf)
-	}()
-
-	mac := hmac.New(sha256.New, []byte(os.Getenv("WEBHOOK_SECRET")))
-	mac.Write(buf.Bytes())
-	expected := append([]byte("sha256="), []byte(fmt.Sprintf("%x", mac.Sum(nil)))...)
-	return hmac.Equal(expected, []byte(r.Header.Get("X-Hub-Signature-256")))
+// dispatch enqueues every plugin enabled for evt's kind as its own
+// dispatcher job typed by the plugin's name, so repeated runs of the same
+// plugin for the same pull request serialize while different plugins, and
+// unrelated pull requests, run concurrently. All jobs for this webhook
+// delivery are enqueued in one EnqueueAll call so a forge retry (triggered by
+// a single plugin hitting the rate limit or a full queue) can't re-run a
+// plugin that already got enqueued on the first attempt.
+func dispatch(d *dispatcher.Dispatcher, f forge.Forge, evt *forge.Event, rr *rule.Rule) error {
+	repo, prNumber := repoAndPR(evt)
+	pctx := plugin.Context{Forge: f, Rule: rr}
+
+	plugins := plugin.For(evt.Kind, rr)
+	jobs := make([]dispatcher.Job, 0, len(plugins))
+	for _, p := range plugins {
+		p := p
+		jobs = append(jobs, dispatcher.Job{
+			Repository: repo,
+			PRNumber:   prNumber,
+			Type:       dispatcher.JobType(p.Name),
+			Run: func(ctx context.Context) {
+				p.Handler(ctx, pctx, evt)
+			},
+		})
+	}
+	return d.EnqueueAll(jobs)
 }
 
-// Integration for slack
-func sendToSlack(ctx context.Context, webhookURL, message string) error {
-	body, err := json.Marshal(map[string]string{
-		"text": message,
-	})
-	if err != nil {
-		return fmt.Errorf("Failed to marshal body: %w", err)
+func repoAndPR(evt *forge.Event) (string, int) {
+	switch evt.Kind {
+	case forge.EventPullRequest:
+		return evt.PullRequest.Repository.FullName, evt.PullRequest.Number
+	case forge.EventPush:
+		return evt.Push.Repository.FullName, 0
+	case forge.EventIssueComment:
+		return evt.IssueComment.Repository.FullName, evt.IssueComment.Issue.Number
+	case forge.EventPullRequestReview:
+		return evt.Review.Repository.FullName, evt.Review.Number
+	default:
+		return "", 0
 	}
+}
 
-	c, timeout := context.WithTimeout(ctx, 5*time.Second)
-	defer timeout()
+func successResponse(w http.ResponseWriter) {
+	message := "Accepted"
 
-	req, err := http.NewRequestWithContext(c, http.MethodPost, webhookURL, bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("Failed to make slack request: %w", err)
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("Failed to get slack response: %w", err)
-	}
-	resp.Body.Close()
-	return nil
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Content-Length", fmt.Sprint(len(message)))
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, message)
 }
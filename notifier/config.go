@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// envRefPattern matches `${VAR_NAME}` references inside a provider URL.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// FromConfig builds the list of configured Provider and their per-provider
+// event filters from a repository's `.doorkeeper.yml` notifications block.
+func FromConfig(cfg rule.Notifications) ([]Provider, map[string][]EventKind, error) {
+	providers := make([]Provider, 0, len(cfg.Providers))
+	filters := make(map[string][]EventKind, len(cfg.Providers))
+
+	for _, p := range cfg.Providers {
+		url := expandEnv(p.URL)
+		if url == "" {
+			return nil, nil, fmt.Errorf("notification provider %q has no url configured", p.Name)
+		}
+
+		provider, err := newProvider(p.Type, url, p.Template)
+		if err != nil {
+			return nil, nil, fmt.Errorf("notification provider %q: %w", p.Name, err)
+		}
+
+		providers = append(providers, provider)
+		filters[provider.Name()] = toEventKinds(p.Events)
+	}
+
+	return providers, filters, nil
+}
+
+func newProvider(kind, url, template string) (Provider, error) {
+	switch kind {
+	case "slack":
+		return &Slack{WebhookURL: url, Template: template}, nil
+	case "discord":
+		return &Discord{WebhookURL: url, Template: template}, nil
+	case "teams":
+		return &Teams{WebhookURL: url, Template: template}, nil
+	case "matrix":
+		return &Matrix{WebhookURL: url, Template: template}, nil
+	case "dingtalk":
+		return &DingTalk{WebhookURL: url, Template: template}, nil
+	case "webhook":
+		return &Webhook{WebhookURL: url, Template: template}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", kind)
+	}
+}
+
+func toEventKinds(events []string) []EventKind {
+	kinds := make([]EventKind, len(events))
+	for i, e := range events {
+		kinds[i] = EventKind(e)
+	}
+	return kinds
+}
+
+// expandEnv replaces `${VAR_NAME}` references in s with the corresponding
+// environment variable value, so `.doorkeeper.yml` never needs to embed a
+// secret directly.
+func expandEnv(s string) string {
+	return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRefPattern.FindStringSubmatch(ref)[1]
+		return os.Getenv(name)
+	})
+}
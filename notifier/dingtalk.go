@@ -0,0 +1,25 @@
+package notifier
+
+import "context"
+
+// DingTalk delivers events as DingTalk/Feishu custom robot markdown messages.
+type DingTalk struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (d *DingTalk) Name() string {
+	return "dingtalk"
+}
+
+// Send implements Provider.
+func (d *DingTalk) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, d.WebhookURL, map[string]interface{}{
+		"msgtype": "markdown",
+		"markdown": map[string]string{
+			"title": event.Title,
+			"text":  render(d.Template, event),
+		},
+	})
+}
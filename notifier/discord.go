@@ -0,0 +1,27 @@
+package notifier
+
+import "context"
+
+// Discord delivers events as Discord webhook embeds.
+type Discord struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (d *Discord) Name() string {
+	return "discord"
+}
+
+// Send implements Provider.
+func (d *Discord) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, d.WebhookURL, map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"title":       event.Title,
+				"description": render(d.Template, event),
+				"url":         event.URL,
+			},
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// postJSON marshals body and POSTs it to url, treating any non-2xx response as an error.
+func postJSON(ctx context.Context, url string, body interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	c, timeout := context.WithTimeout(ctx, 5*time.Second)
+	defer timeout()
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+)
+
+// Matrix delivers events as m.room.message events posted to a Matrix room via
+// its Client-Server API send endpoint (WebhookURL already includes the
+// room ID and access token as doorkeeper never holds a long-lived session).
+type Matrix struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (m *Matrix) Name() string {
+	return "matrix"
+}
+
+// Send implements Provider.
+func (m *Matrix) Send(ctx context.Context, event Event) error {
+	body := render(m.Template, event)
+	return postJSON(ctx, m.WebhookURL, map[string]string{
+		"msgtype":        "m.text",
+		"body":           body,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": fmt.Sprintf("<p>%s</p>", body),
+	})
+}
@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+)
+
+// EventKind identifies which doorkeeper event a notification is about.
+type EventKind string
+
+const (
+	EventValidationFailed  EventKind = "validation_failed"
+	EventReleaseNotePosted EventKind = "release_note_posted"
+	EventTagPushed         EventKind = "tag_pushed"
+)
+
+// Event is the forge-neutral payload handed to every Provider.
+type Event struct {
+	Kind       EventKind
+	Repository string
+	Title      string
+	URL        string
+	Message    string
+}
+
+// Provider delivers an Event to a single destination (Slack, Discord, ...).
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// Dispatch fan-outs event to every provider that has EventKind in its filter,
+// collecting (not stopping on) individual provider errors.
+func Dispatch(ctx context.Context, providers []Provider, filters map[string][]EventKind, event Event) []error {
+	var errs []error
+	for _, p := range providers {
+		if !accepts(filters[p.Name()], event.Kind) {
+			continue
+		}
+		if err := sendWithRetry(ctx, p, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+	}
+	return errs
+}
+
+func accepts(filter []EventKind, kind EventKind) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, k := range filter {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
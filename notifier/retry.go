@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// retryAttempts and retryBaseDelay bound the per-provider exponential backoff.
+const (
+	retryAttempts  = 3
+	retryBaseDelay = 500 * time.Millisecond
+)
+
+// sendWithRetry calls p.Send, retrying with exponential backoff on failure.
+func sendWithRetry(ctx context.Context, p Provider, event Event) error {
+	var err error
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		if err = p.Send(ctx, event); err == nil {
+			return nil
+		}
+		if attempt == retryAttempts-1 {
+			break
+		}
+		select {
+		case <-time.After(retryBaseDelay << attempt):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
@@ -0,0 +1,21 @@
+package notifier
+
+import "context"
+
+// Slack delivers events as Slack incoming-webhook messages.
+type Slack struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+// Send implements Provider.
+func (s *Slack) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.WebhookURL, map[string]string{
+		"text": render(s.Template, event),
+	})
+}
@@ -0,0 +1,32 @@
+package notifier
+
+import "context"
+
+// Teams delivers events as Microsoft Teams MessageCard payloads.
+type Teams struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (t *Teams) Name() string {
+	return "teams"
+}
+
+// Send implements Provider.
+func (t *Teams) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, t.WebhookURL, map[string]interface{}{
+		"@type":    "MessageCard",
+		"@context": "http://schema.org/extensions",
+		"summary":  event.Title,
+		"title":    event.Title,
+		"text":     render(t.Template, event),
+		"potentialAction": []map[string]interface{}{
+			{
+				"@type":   "OpenUri",
+				"name":    "Open",
+				"targets": []map[string]string{{"os": "default", "uri": event.URL}},
+			},
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import "strings"
+
+// defaultTemplate is used by providers when no per-provider template is configured.
+const defaultTemplate = ":robot: [{{repository}}] {{message}}"
+
+// render expands the doorkeeper-wide placeholders ({{repository}}, {{title}},
+// {{url}}, {{message}}) in tmpl against event. An empty tmpl falls back to
+// defaultTemplate.
+func render(tmpl string, event Event) string {
+	if tmpl == "" {
+		tmpl = defaultTemplate
+	}
+	replacer := strings.NewReplacer(
+		"{{repository}}", event.Repository,
+		"{{title}}", event.Title,
+		"{{url}}", event.URL,
+		"{{message}}", event.Message,
+	)
+	return replacer.Replace(tmpl)
+}
@@ -0,0 +1,26 @@
+package notifier
+
+import "context"
+
+// Webhook delivers events as a plain JSON POST for teams without a chat
+// integration of their own, e.g. an internal automation endpoint.
+type Webhook struct {
+	WebhookURL string
+	Template   string
+}
+
+// Name implements Provider.
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+// Send implements Provider.
+func (w *Webhook) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, w.WebhookURL, map[string]string{
+		"kind":       string(event.Kind),
+		"repository": event.Repository,
+		"title":      event.Title,
+		"url":        event.URL,
+		"message":    render(w.Template, event),
+	})
+}
@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"context"
+	"log"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/notifier"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// notifyValidationFailed fans the validation failure out to every notification
+// provider configured in the repository's `.doorkeeper.yml`.
+func notifyValidationFailed(ctx context.Context, evt entity.PullRequestEvent, r *rule.Rule, cause error) {
+	dispatchNotification(ctx, r, notifier.Event{
+		Kind:       notifier.EventValidationFailed,
+		Repository: evt.Repository.FullName,
+		Title:      evt.PullRequest.Title,
+		Message:    cause.Error(),
+	})
+}
+
+// notifyReleaseNotePosted fans a release-note-posted event out to every
+// notification provider configured in the repository's `.doorkeeper.yml`.
+func notifyReleaseNotePosted(ctx context.Context, repository, title string, r *rule.Rule) {
+	dispatchNotification(ctx, r, notifier.Event{
+		Kind:       notifier.EventReleaseNotePosted,
+		Repository: repository,
+		Title:      title,
+	})
+}
+
+// notifyTagPushed fans a tag-pushed event out to every notification provider
+// configured in the repository's `.doorkeeper.yml`.
+func notifyTagPushed(ctx context.Context, repository, tag string, r *rule.Rule) {
+	dispatchNotification(ctx, r, notifier.Event{
+		Kind:       notifier.EventTagPushed,
+		Repository: repository,
+		Title:      tag,
+	})
+}
+
+func dispatchNotification(ctx context.Context, r *rule.Rule, event notifier.Event) {
+	providers, filters, err := notifier.FromConfig(r.Notifications)
+	if err != nil {
+		log.Println("Failed to build notification providers:", err)
+		return
+	}
+	for _, err := range notifier.Dispatch(ctx, providers, filters, event) {
+		log.Println("Failed to deliver notification:", err)
+	}
+}
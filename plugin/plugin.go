@@ -0,0 +1,61 @@
+// Package plugin is a Prow-style registry that lets handlers for webhook
+// events be added without editing handler.WebhookHandler. First-party
+// plugins (validate, release_notes, tag_release) and third-party ones
+// register themselves from an init function; WebhookHandler fans each
+// decoded event out to every plugin enabled for the repository.
+package plugin
+
+import (
+	"context"
+
+	"github.com/ysugimoto/doorkeeper/forge"
+	"github.com/ysugimoto/doorkeeper/rule"
+)
+
+// Context carries the per-request state a plugin needs to act.
+type Context struct {
+	Forge forge.Forge
+	Rule  *rule.Rule
+}
+
+// Handler reacts to a single decoded webhook event.
+type Handler func(ctx context.Context, pctx Context, evt *forge.Event)
+
+// Plugin is a named Handler enabled for a set of event kinds.
+type Plugin struct {
+	Name    string
+	Events  []forge.EventKind
+	Handler Handler
+}
+
+var registry []Plugin
+
+// Register adds a plugin to the registry. Adapters call this from an init
+// function so dropping a new file in the tree is enough to wire it up.
+func Register(name string, events []forge.EventKind, handler Handler) {
+	registry = append(registry, Plugin{Name: name, Events: events, Handler: handler})
+}
+
+// For returns every registered plugin that handles kind and is enabled by rr.
+func For(kind forge.EventKind, rr *rule.Rule) []Plugin {
+	var matched []Plugin
+	for _, p := range registry {
+		if !handlesKind(p.Events, kind) {
+			continue
+		}
+		if !rr.PluginEnabled(p.Name) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	return matched
+}
+
+func handlesKind(events []forge.EventKind, kind forge.EventKind) bool {
+	for _, e := range events {
+		if e == kind {
+			return true
+		}
+	}
+	return false
+}
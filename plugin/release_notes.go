@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/forge"
+)
+
+func init() {
+	Register("release_notes", []forge.EventKind{forge.EventPullRequest}, releaseNotes)
+}
+
+// releaseNotes is the first-party "release_notes" plugin: on open it drafts
+// a release note once the base branch matches the validation target, and on
+// synchronize it refreshes the draft once the base branch matches the
+// release note target.
+func releaseNotes(ctx context.Context, pctx Context, evt *forge.Event) {
+	pr := evt.PullRequest
+	switch pr.Action {
+	case "opened":
+		if ok, _ := pctx.Rule.MatchValidateBranch(pr.BaseBranch()); !ok {
+			return
+		}
+	case "synchronize":
+		if ok, _ := pctx.Rule.MatchReleaseNoteBranch(pr.BaseBranch()); !ok {
+			return
+		}
+	default:
+		return
+	}
+	factoryRelaseNotes(ctx, pctx.Forge, *pr, pctx)
+}
+
+func factoryRelaseNotes(ctx context.Context, f forge.Forge, evt entity.PullRequestEvent, pctx Context) {
+	ctx, timeout := context.WithTimeout(ctx, 3*time.Minute)
+	defer timeout()
+
+	commits, err := f.ListCommits(ctx, evt.CommitsURL())
+	if err != nil {
+		log.Println("Failed to list commits for release note:", err)
+		return
+	}
+
+	body := strings.Join(commits, "\n")
+	if err := f.Comment(ctx, evt.CommentURL(), fmt.Sprintf(":memo: Release note draft\n%s\n", body)); err != nil {
+		log.Println("Failed to post release note draft:", err)
+		return
+	}
+
+	notifyReleaseNotePosted(ctx, evt.Repository.FullName, evt.PullRequest.Title, pctx.Rule)
+}
@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/ysugimoto/doorkeeper/forge"
+)
+
+func init() {
+	Register("retest", []forge.EventKind{forge.EventIssueComment}, retest)
+}
+
+// retest is a first-party example of an issue_comment plugin: a maintainer
+// commenting "/retest" on a pull request re-runs the validate plugin,
+// exactly the kind of slash-command a third party can add by dropping in a
+// new plugin file.
+func retest(ctx context.Context, pctx Context, evt *forge.Event) {
+	comment := evt.IssueComment
+	if comment.Action != "created" || !comment.IsPullRequest() {
+		return
+	}
+	if strings.TrimSpace(comment.Comment.Body) != "/retest" {
+		return
+	}
+
+	if err := pctx.Forge.Comment(ctx, comment.CommentURL(), ":robot: Re-running validation..."); err != nil {
+		log.Println("Failed to acknowledge /retest comment:", err)
+	}
+
+	pr, err := pctx.Forge.GetPullRequest(ctx, comment.Issue.PullRequest.URL)
+	if err != nil {
+		log.Println("Failed to fetch pull request for /retest:", err)
+		return
+	}
+	validatePullRequest(ctx, pctx.Forge, *pr, pctx)
+}
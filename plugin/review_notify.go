@@ -0,0 +1,23 @@
+package plugin
+
+import (
+	"context"
+	"log"
+
+	"github.com/ysugimoto/doorkeeper/forge"
+)
+
+func init() {
+	Register("review_notify", []forge.EventKind{forge.EventPullRequestReview}, reviewNotify)
+}
+
+// reviewNotify is a first-party example of a pull_request_review plugin: it
+// logs submitted reviews so third parties can see the hook is wired before
+// building something heavier (e.g. auto-merge on enough approvals).
+func reviewNotify(ctx context.Context, pctx Context, evt *forge.Event) {
+	review := evt.Review
+	if review.Action != "submitted" {
+		return
+	}
+	log.Printf("pull request #%d on %s received a %q review", review.Number, review.Repository.FullName, review.Review.State)
+}
@@ -0,0 +1,48 @@
+package plugin
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	"github.com/ysugimoto/doorkeeper/forge"
+)
+
+func init() {
+	Register("tag_release", []forge.EventKind{forge.EventPush}, tagRelease)
+}
+
+// tagRelease is the first-party "tag_release" plugin: it creates a release
+// from the commits reachable from a pushed tag that matches the repository's
+// tag pattern.
+func tagRelease(ctx context.Context, pctx Context, evt *forge.Event) {
+	push := evt.Push
+	if !strings.HasPrefix(push.Ref, "refs/tags/") {
+		return
+	}
+	tag := strings.TrimPrefix(push.Ref, "refs/tags/")
+	if ok, _ := pctx.Rule.MatchTag(tag); !ok {
+		return
+	}
+	processTagPushEvent(ctx, pctx.Forge, *push, pctx)
+}
+
+func processTagPushEvent(ctx context.Context, f forge.Forge, evt entity.PushEvent, pctx Context) {
+	ctx, timeout := context.WithTimeout(ctx, 3*time.Minute)
+	defer timeout()
+
+	tag := strings.TrimPrefix(evt.Ref, "refs/tags/")
+
+	if err := f.CreateRelease(ctx, evt.ReleasesURL(), entity.Release{
+		TagName: tag,
+		Name:    tag,
+		Body:    strings.Join(evt.CommitMessages(), "\n"),
+	}); err != nil {
+		log.Println("Failed to create release:", err)
+		return
+	}
+
+	notifyTagPushed(ctx, evt.Repository.FullName, tag, pctx.Rule)
+}
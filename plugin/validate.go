@@ -0,0 +1,138 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ysugimoto/doorkeeper/entity"
+	dkerrors "github.com/ysugimoto/doorkeeper/errors"
+	"github.com/ysugimoto/doorkeeper/forge"
+)
+
+func init() {
+	Register("validate", []forge.EventKind{forge.EventPullRequest}, validate)
+}
+
+// validate is the first-party "validate" plugin: it checks the pull
+// request's title and description against the repository's rules on open
+// and edit, reporting the result as a commit status and review comment.
+func validate(ctx context.Context, pctx Context, evt *forge.Event) {
+	pr := evt.PullRequest
+	switch pr.Action {
+	case "opened", "edited":
+	default:
+		return
+	}
+	validatePullRequest(ctx, pctx.Forge, *pr, pctx)
+}
+
+func validatePullRequest(ctx context.Context, f forge.Forge, evt entity.PullRequestEvent, pctx Context) {
+	ctx, timeout := context.WithTimeout(ctx, 3*time.Minute)
+	defer timeout()
+
+	// Firstly, create status as "pending"
+	if err := f.Status(ctx, evt.StatusURL(), entity.GithubStatus{
+		Status:      "pending",
+		Context:     "grc:validate",
+		Description: "validate pull request",
+	}); err != nil {
+		log.Println("Failed to create status as pending:", err)
+		return
+	}
+
+	var statusErr error
+	defer func() {
+		switch {
+		case statusErr == nil:
+			// Update to "success"
+			if err := f.Status(ctx, evt.StatusURL(), entity.GithubStatus{
+				Status:      "success",
+				Context:     "grc:validate",
+				Description: "validate pull request",
+			}); err != nil {
+				log.Println("Failed to update shcek status as success:", err)
+			}
+		case isTooManyRequests(statusErr):
+			// The forge is rate limiting us: leave the status as "pending" and
+			// don't comment so a later retry can still succeed cleanly.
+			dkerrors.RecordTooManyRequests()
+			log.Println("Rate limited while validating pull request:", statusErr)
+		case isServiceFault(statusErr):
+			dkerrors.RecordServiceFault()
+			correlationID := dkerrors.NewCorrelationID()
+			log.Printf("Service fault while validating pull request [%s]: %v", correlationID, statusErr)
+			if err := f.Status(ctx, evt.StatusURL(), entity.GithubStatus{
+				Status:      "error",
+				Context:     "grc:validate",
+				Description: "doorkeeper internal error, retry later (" + correlationID + ")",
+			}); err != nil {
+				log.Println("Failed to update check status as error:", err)
+			}
+		default:
+			dkerrors.RecordUserError()
+			// Update to "failure" status
+			if err := f.Status(ctx, evt.StatusURL(), entity.GithubStatus{
+				Status:      "failure",
+				Context:     "grc:validate",
+				Description: "validate pull request",
+			}); err != nil {
+				log.Println("Failed to update check status as failure:", err)
+			}
+			// And add review comment what is invalid
+			if err := f.Review(ctx, evt.ReviewURL(), entity.GithubReview{
+				Body:  statusErr.Error(),
+				Event: "COMMENT",
+			}); err != nil {
+				log.Println("Failed to send comment:", err)
+			}
+			notifyValidationFailed(ctx, evt, pctx.Rule, statusErr)
+		}
+	}()
+
+	var faults []error
+	var userErrs []string
+	var rateLimited error
+	for _, err := range []error{
+		pctx.Rule.ValidateTitle(evt.PullRequest.Title),
+		pctx.Rule.ValidateDescription(evt.PullRequest.Body),
+	} {
+		switch {
+		case err == nil:
+		case isTooManyRequests(err):
+			rateLimited = err
+		case isServiceFault(err):
+			faults = append(faults, err)
+		default:
+			userErrs = append(userErrs, "- "+err.Error())
+		}
+	}
+
+	// A service fault takes priority: it means doorkeeper (or a dependency
+	// like the forge API) is broken, not the pull request itself.
+	switch {
+	case len(faults) > 0:
+		statusErr = faults[0]
+	case rateLimited != nil:
+		statusErr = rateLimited
+	case len(userErrs) > 0:
+		statusErr = dkerrors.NewUserError(fmt.Errorf(
+			":robot: PR Validation Failed!\n%s\n",
+			strings.Join(userErrs, "\n"),
+		))
+	}
+	// passed
+}
+
+func isServiceFault(err error) bool {
+	var fault *dkerrors.ServiceFault
+	return errors.As(err, &fault)
+}
+
+func isTooManyRequests(err error) bool {
+	var rateLimited *dkerrors.TooManyRequestsError
+	return errors.As(err, &rateLimited)
+}
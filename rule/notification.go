@@ -0,0 +1,17 @@
+package rule
+
+// NotificationProvider configures a single outgoing notification destination.
+// URL may reference an environment variable instead of embedding the secret
+// directly, e.g. `url: ${SLACK_WEBHOOK_URL}`.
+type NotificationProvider struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	URL      string   `yaml:"url"`
+	Events   []string `yaml:"events"`
+	Template string   `yaml:"template"`
+}
+
+// Notifications is the `notifications:` block of `.doorkeeper.yml`.
+type Notifications struct {
+	Providers []NotificationProvider `yaml:"providers"`
+}
@@ -0,0 +1,118 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ysugimoto/doorkeeper/errors"
+)
+
+// SettingFile is the path of doorkeeper's per-repository config file.
+const SettingFile = "/.doorkeeper.yml"
+
+// ValidationRule configures pull request title/description validation.
+type ValidationRule struct {
+	Disable          bool     `yaml:"disable"`
+	TitlePattern     string   `yaml:"title_pattern"`
+	RequiredSections []string `yaml:"required_sections"`
+	TargetBranches   []string `yaml:"target_branches"`
+}
+
+// ReleaseNoteRule configures automatic release note generation.
+type ReleaseNoteRule struct {
+	Disable        bool     `yaml:"disable"`
+	TargetBranches []string `yaml:"target_branches"`
+	TagPattern     string   `yaml:"tag_pattern"`
+}
+
+// Rule is the parsed representation of a repository's `.doorkeeper.yml`.
+type Rule struct {
+	Validation    ValidationRule  `yaml:"validation"`
+	ReleaseNote   ReleaseNoteRule `yaml:"release_note"`
+	Notifications Notifications   `yaml:"notifications"`
+	Plugins       map[string]bool `yaml:"plugins"`
+}
+
+// DefaultRule is used when a repository has no `.doorkeeper.yml` of its own.
+var DefaultRule = &Rule{}
+
+// ValidateTitle checks the pull request title against the configured pattern.
+func (r *Rule) ValidateTitle(title string) error {
+	if r.Validation.TitlePattern == "" {
+		return nil
+	}
+	matched, err := regexp.MatchString(r.Validation.TitlePattern, title)
+	if err != nil {
+		return errors.NewServiceFault(fmt.Errorf("invalid title_pattern: %w", err))
+	}
+	if !matched {
+		return errors.NewUserError(fmt.Errorf("title %q does not match pattern %q", title, r.Validation.TitlePattern))
+	}
+	return nil
+}
+
+// ValidateDescription checks that the pull request body contains every required section.
+func (r *Rule) ValidateDescription(body string) error {
+	for _, section := range r.Validation.RequiredSections {
+		matched, err := regexp.MatchString(section, body)
+		if err != nil {
+			return errors.NewServiceFault(fmt.Errorf("invalid required_sections entry %q: %w", section, err))
+		}
+		if !matched {
+			return errors.NewUserError(fmt.Errorf("description is missing required section %q", section))
+		}
+	}
+	return nil
+}
+
+// MatchValidateBranch reports whether branch is targeted by the validation rule.
+func (r *Rule) MatchValidateBranch(branch string) (bool, error) {
+	return matchBranch(r.Validation.TargetBranches, branch)
+}
+
+// MatchReleaseNoteBranch reports whether branch is targeted by the release note rule.
+func (r *Rule) MatchReleaseNoteBranch(branch string) (bool, error) {
+	return matchBranch(r.ReleaseNote.TargetBranches, branch)
+}
+
+// MatchTag reports whether tag matches the configured release note tag pattern.
+func (r *Rule) MatchTag(tag string) (bool, error) {
+	if r.ReleaseNote.TagPattern == "" {
+		return true, nil
+	}
+	return regexp.MatchString(r.ReleaseNote.TagPattern, tag)
+}
+
+// PluginEnabled reports whether the named plugin is enabled for this
+// repository. An explicit `plugins:` entry always wins; first-party plugins
+// otherwise fall back to their dedicated disable flag, and third-party
+// plugins default to enabled.
+func (r *Rule) PluginEnabled(name string) bool {
+	if enabled, ok := r.Plugins[name]; ok {
+		return enabled
+	}
+	switch name {
+	case "validate":
+		return !r.Validation.Disable
+	case "release_notes", "tag_release":
+		return !r.ReleaseNote.Disable
+	default:
+		return true
+	}
+}
+
+func matchBranch(patterns []string, branch string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, p := range patterns {
+		matched, err := regexp.MatchString(p, branch)
+		if err != nil {
+			return false, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}